@@ -0,0 +1,221 @@
+// Package config centraliza la configuración de los tres subcomandos
+// (receiver, recommender, all), con precedencia flags > variables de
+// entorno > fichero YAML opcional > valores por defecto.
+package config
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config agrupa todos los parámetros configurables de ambos servicios.
+type Config struct {
+	ReceiverTCPPort     string
+	ReceiverHTTPPort    string
+	RecommenderHTTPPort string
+	ReceiverAddress     string // host:puerto del receiver, usado por el recommender para enviar resultados
+
+	DatasetPath      string
+	SnapshotPath     string
+	SnapshotInterval time.Duration
+
+	CORSOrigins []string
+	MaxConns    int
+
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+
+	SenderDeadline time.Duration
+	SenderRetries  int
+	SenderBackoff  time.Duration
+
+	ConfigFile string
+}
+
+func defaults() *Config {
+	return &Config{
+		ReceiverTCPPort:     "8080",
+		ReceiverHTTPPort:    "9090",
+		RecommenderHTTPPort: "8082",
+		ReceiverAddress:     "localhost:8080",
+		DatasetPath:         "dataset2.csv",
+		SnapshotPath:        "dataset2.gob",
+		SnapshotInterval:    time.Minute,
+		MaxConns:            64,
+		ReadTimeout:         10 * time.Second,
+		WriteTimeout:        10 * time.Second,
+		SenderDeadline:      10 * time.Second,
+		SenderRetries:       3,
+		SenderBackoff:       100 * time.Millisecond,
+	}
+}
+
+// Load construye la configuración de un subcomando a partir de, por orden
+// de prioridad creciente: los valores por defecto, un fichero YAML opcional,
+// variables de entorno con el prefijo TFC_, y por último los flags de args.
+// Los flags se definen con el valor ya calculado (YAML+env) como default, de
+// modo que sólo ganan si el usuario los pasó explícitamente en la línea de
+// comandos.
+func Load(serviceName string, args []string) (*Config, error) {
+	cfg := defaults()
+
+	configPath := preScanConfigFlag(args)
+	if configPath == "" {
+		configPath = os.Getenv("TFC_CONFIG_FILE")
+	}
+	if configPath != "" {
+		if err := applyYAMLFile(cfg, configPath); err != nil {
+			return nil, fmt.Errorf("config: error al leer %s: %w", configPath, err)
+		}
+		cfg.ConfigFile = configPath
+	}
+
+	applyEnv(cfg)
+
+	fs := flag.NewFlagSet(serviceName, flag.ContinueOnError)
+	fs.StringVar(&cfg.ConfigFile, "config", cfg.ConfigFile, "ruta a un fichero de configuración YAML opcional")
+	fs.StringVar(&cfg.ReceiverTCPPort, "receiver-tcp-port", cfg.ReceiverTCPPort, "puerto TCP del receiver")
+	fs.StringVar(&cfg.ReceiverHTTPPort, "receiver-http-port", cfg.ReceiverHTTPPort, "puerto HTTP del visor del receiver")
+	fs.StringVar(&cfg.RecommenderHTTPPort, "recommender-http-port", cfg.RecommenderHTTPPort, "puerto HTTP del recommender")
+	fs.StringVar(&cfg.ReceiverAddress, "receiver-address", cfg.ReceiverAddress, "host:puerto del receiver visto desde el recommender")
+	fs.StringVar(&cfg.DatasetPath, "dataset-path", cfg.DatasetPath, "ruta del CSV con el dataset de productos")
+	fs.StringVar(&cfg.SnapshotPath, "snapshot-path", cfg.SnapshotPath, "ruta del snapshot del índice en disco")
+	fs.DurationVar(&cfg.SnapshotInterval, "snapshot-interval", cfg.SnapshotInterval, "cada cuánto se vuelca el índice al snapshot")
+	fs.IntVar(&cfg.MaxConns, "max-conns", cfg.MaxConns, "número máximo de conexiones TCP atendidas a la vez")
+	fs.DurationVar(&cfg.ReadTimeout, "read-timeout", cfg.ReadTimeout, "deadline para leer un frame en una conexión TCP")
+	fs.DurationVar(&cfg.WriteTimeout, "write-timeout", cfg.WriteTimeout, "deadline para escribir un frame en una conexión TCP")
+	fs.DurationVar(&cfg.SenderDeadline, "sender-deadline", cfg.SenderDeadline, "deadline por intento al hablar con el receiver")
+	fs.IntVar(&cfg.SenderRetries, "sender-retries", cfg.SenderRetries, "número de intentos al hablar con el receiver")
+	fs.DurationVar(&cfg.SenderBackoff, "sender-backoff", cfg.SenderBackoff, "backoff base entre reintentos")
+	corsOrigins := fs.String("cors-origins", strings.Join(cfg.CORSOrigins, ","), "orígenes CORS permitidos, separados por comas (vacío = cualquiera)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	cfg.CORSOrigins = splitNonEmpty(*corsOrigins)
+
+	return cfg, nil
+}
+
+// preScanConfigFlag busca -config/--config en args sin pasar por flag.Parse,
+// porque el propio fichero YAML debe poder influir en los defaults que luego
+// se registran en el FlagSet.
+func preScanConfigFlag(args []string) string {
+	for i, arg := range args {
+		switch {
+		case arg == "-config" || arg == "--config":
+			if i+1 < len(args) {
+				return args[i+1]
+			}
+		case strings.HasPrefix(arg, "-config="):
+			return strings.TrimPrefix(arg, "-config=")
+		case strings.HasPrefix(arg, "--config="):
+			return strings.TrimPrefix(arg, "--config=")
+		}
+	}
+	return ""
+}
+
+func applyEnv(cfg *Config) {
+	if v := os.Getenv("TFC_RECEIVER_TCP_PORT"); v != "" {
+		cfg.ReceiverTCPPort = v
+	}
+	if v := os.Getenv("TFC_RECEIVER_HTTP_PORT"); v != "" {
+		cfg.ReceiverHTTPPort = v
+	}
+	if v := os.Getenv("TFC_RECOMMENDER_HTTP_PORT"); v != "" {
+		cfg.RecommenderHTTPPort = v
+	}
+	if v := os.Getenv("TFC_RECEIVER_ADDRESS"); v != "" {
+		cfg.ReceiverAddress = v
+	}
+	if v := os.Getenv("TFC_DATASET_PATH"); v != "" {
+		cfg.DatasetPath = v
+	}
+	if v := os.Getenv("TFC_SNAPSHOT_PATH"); v != "" {
+		cfg.SnapshotPath = v
+	}
+	if v := os.Getenv("TFC_SNAPSHOT_INTERVAL"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			cfg.SnapshotInterval = d
+		}
+	}
+	if v := os.Getenv("TFC_MAX_CONNS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			cfg.MaxConns = n
+		}
+	}
+	if v := os.Getenv("TFC_CORS_ORIGINS"); v != "" {
+		cfg.CORSOrigins = splitNonEmpty(v)
+	}
+}
+
+// applyYAMLFile aplica un fichero de configuración con sintaxis "clave:
+// valor" por línea (un subconjunto de YAML plano, sin anidamiento ni listas
+// con guiones), suficiente para las claves escalares que expone Config.
+func applyYAMLFile(cfg *Config, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		switch key {
+		case "receiver_tcp_port":
+			cfg.ReceiverTCPPort = value
+		case "receiver_http_port":
+			cfg.ReceiverHTTPPort = value
+		case "recommender_http_port":
+			cfg.RecommenderHTTPPort = value
+		case "receiver_address":
+			cfg.ReceiverAddress = value
+		case "dataset_path":
+			cfg.DatasetPath = value
+		case "snapshot_path":
+			cfg.SnapshotPath = value
+		case "snapshot_interval":
+			if d, err := time.ParseDuration(value); err == nil {
+				cfg.SnapshotInterval = d
+			}
+		case "max_conns":
+			if n, err := strconv.Atoi(value); err == nil {
+				cfg.MaxConns = n
+			}
+		case "cors_origins":
+			cfg.CORSOrigins = splitNonEmpty(value)
+		}
+	}
+	return scanner.Err()
+}
+
+func splitNonEmpty(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	result := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if trimmed := strings.TrimSpace(p); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
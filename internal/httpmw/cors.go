@@ -0,0 +1,41 @@
+// Package httpmw contiene middleware HTTP compartido entre el receiver y el
+// recommender.
+package httpmw
+
+import "net/http"
+
+// CORS construye un middleware que añade las cabeceras CORS necesarias para
+// que un frontend en otro origen pueda llamar al endpoint. Si origins está
+// vacío, permite cualquier origen ("*"), que es el comportamiento histórico.
+func CORS(origins []string) func(http.Handler) http.Handler {
+	allowAll := len(origins) == 0
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := "*"
+			if !allowAll {
+				origin = ""
+				requestOrigin := r.Header.Get("Origin")
+				for _, allowed := range origins {
+					if allowed == requestOrigin {
+						origin = requestOrigin
+						break
+					}
+				}
+			}
+			if origin != "" {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+
+			// Manejar preflight requests (OPTIONS)
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
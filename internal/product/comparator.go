@@ -0,0 +1,90 @@
+package product
+
+import (
+	"strings"
+	"sync"
+)
+
+// Comparator ordena dos productos: negativo si a debe ir antes que b, cero
+// si son equivalentes, positivo si b debe ir antes. Permite experimentar con
+// estrategias de ranking sin tocar el código que arma las recomendaciones.
+type Comparator func(a, b Product) int
+
+var (
+	comparatorMu       sync.RWMutex
+	comparatorRegistry = make(map[string]Comparator)
+)
+
+// RegisterComparator añade (o reemplaza) un comparador con nombre name,
+// disponible para seleccionarse vía el campo "ranker" del endpoint de
+// recomendaciones.
+func RegisterComparator(name string, cmp Comparator) {
+	comparatorMu.Lock()
+	defer comparatorMu.Unlock()
+	comparatorRegistry[name] = cmp
+}
+
+// LookupComparator busca un comparador registrado por nombre.
+func LookupComparator(name string) (Comparator, bool) {
+	comparatorMu.RLock()
+	defer comparatorMu.RUnlock()
+	cmp, ok := comparatorRegistry[name]
+	return cmp, ok
+}
+
+func init() {
+	RegisterComparator("by_stars_desc", byStarsDesc)
+	RegisterComparator("by_stars_then_id", byStarsThenID)
+	RegisterComparator("weighted", weightedScore)
+}
+
+// byStarsDesc es el criterio histórico: gana quien tenga más Stars.
+func byStarsDesc(a, b Product) int {
+	switch {
+	case a.Stars > b.Stars:
+		return -1
+	case a.Stars < b.Stars:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// byStarsThenID añade un desempate estable por ID a byStarsDesc; es el
+// criterio con el que Index pre-ordena byCategory.
+func byStarsThenID(a, b Product) int {
+	if c := byStarsDesc(a, b); c != 0 {
+		return c
+	}
+	return strings.Compare(a.ID, b.ID)
+}
+
+// Pesos del scorer combinado: Stars pesa más, ReviewCount aporta poco por
+// unidad (puede llegar a miles) y Price penaliza linealmente.
+const (
+	weightStars       = 1.0
+	weightReviewCount = 0.001
+	weightPrice       = 0.01
+)
+
+// weightedScore combina Stars con Price y ReviewCount cuando están
+// disponibles, para que un operador pueda priorizar precio o popularidad sin
+// redeploy.
+func weightedScore(a, b Product) int {
+	scoreA, scoreB := weightedProductScore(a), weightedProductScore(b)
+	switch {
+	case scoreA > scoreB:
+		return -1
+	case scoreA < scoreB:
+		return 1
+	default:
+		return strings.Compare(a.ID, b.ID)
+	}
+}
+
+func weightedProductScore(p Product) float64 {
+	score := p.Stars * weightStars
+	score += float64(p.ReviewCount) * weightReviewCount
+	score -= p.Price * weightPrice
+	return score
+}
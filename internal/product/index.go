@@ -0,0 +1,160 @@
+package product
+
+import (
+	"context"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// indexSnapshot es la forma persistida del índice: basta con el mapa por
+// ID, ya que el índice por categoría se reconstruye a partir de él.
+type indexSnapshot struct {
+	ByID map[string]Product
+}
+
+// Index mantiene el dataset en memoria junto con un índice invertido por
+// categoría (ordenado descendente por Stars, con el ID como desempate
+// estable) para que las recomendaciones no tengan que recorrer todo el
+// dataset en cada petición. mu protege ambos mapas para permitir un
+// hot-reload seguro desde un CSV nuevo mientras el servidor sigue sirviendo
+// tráfico.
+type Index struct {
+	mu         sync.RWMutex
+	byID       map[string]Product
+	byCategory map[string][]Product
+}
+
+// NewIndex construye el índice a partir del dataset cargado del CSV o de un
+// snapshot.
+func NewIndex(products map[string]Product) *Index {
+	idx := &Index{byID: products, byCategory: make(map[string][]Product)}
+	idx.rebuildCategoryIndexLocked()
+	return idx
+}
+
+// rebuildCategoryIndexLocked reconstruye byCategory a partir de byID. El
+// llamador debe sostener mu en modo escritura.
+func (idx *Index) rebuildCategoryIndexLocked() {
+	byCategory := make(map[string][]Product)
+	for _, p := range idx.byID {
+		byCategory[p.Category] = append(byCategory[p.Category], p)
+	}
+	for category, products := range byCategory {
+		sort.Slice(products, func(i, j int) bool {
+			if products[i].Stars != products[j].Stars {
+				return products[i].Stars > products[j].Stars
+			}
+			return products[i].ID < products[j].ID
+		})
+		byCategory[category] = products
+	}
+	idx.byCategory = byCategory
+}
+
+// Lookup devuelve el producto con el ID dado, si existe.
+func (idx *Index) Lookup(id string) (Product, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	p, ok := idx.byID[id]
+	return p, ok
+}
+
+// TopKByCategory devuelve hasta k productos de category, excluyendo los IDs
+// marcados en exclude. Con cmp nil reutiliza el orden pre-calculado del
+// índice (O(k)); con un cmp distinto copia y reordena la categoría primero,
+// para poder experimentar con otros criterios de ranking sin redeploy.
+func (idx *Index) TopKByCategory(category string, k int, exclude map[string]bool, cmp Comparator) []Product {
+	idx.mu.RLock()
+	candidates := idx.byCategory[category]
+	if cmp != nil {
+		reordered := make([]Product, len(candidates))
+		copy(reordered, candidates)
+		candidates = reordered
+	}
+	idx.mu.RUnlock()
+
+	if cmp != nil {
+		sort.SliceStable(candidates, func(i, j int) bool { return cmp(candidates[i], candidates[j]) < 0 })
+	}
+
+	results := make([]Product, 0, k)
+	for _, p := range candidates {
+		if exclude[p.ID] {
+			continue
+		}
+		results = append(results, p)
+		if len(results) == k {
+			break
+		}
+	}
+	return results
+}
+
+// Reload sustituye el dataset en caliente y reconstruye el índice por
+// categoría bajo el lock de escritura, de modo que los lectores nunca vean
+// un estado a medio reconstruir.
+func (idx *Index) Reload(products map[string]Product) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.byID = products
+	idx.rebuildCategoryIndexLocked()
+}
+
+// SaveSnapshot vuelca el dataset a un fichero gob para que un reinicio pueda
+// recuperarlo sin volver a parsear el CSV.
+func (idx *Index) SaveSnapshot(path string) error {
+	idx.mu.RLock()
+	snap := indexSnapshot{ByID: idx.byID}
+	idx.mu.RUnlock()
+
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("snapshot: error al crear el fichero temporal: %w", err)
+	}
+	if err := gob.NewEncoder(file).Encode(snap); err != nil {
+		file.Close()
+		return fmt.Errorf("snapshot: error al codificar: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("snapshot: error al cerrar: %w", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// LoadSnapshot carga un snapshot previamente guardado con SaveSnapshot.
+func LoadSnapshot(path string) (map[string]Product, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var snap indexSnapshot
+	if err := gob.NewDecoder(file).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("snapshot: error al decodificar: %w", err)
+	}
+	return snap.ByID, nil
+}
+
+// PersistPeriodically guarda un snapshot del índice cada interval hasta que
+// ctx se cancela.
+func (idx *Index) PersistPeriodically(ctx context.Context, path string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := idx.SaveSnapshot(path); err != nil {
+				fmt.Println("Error al guardar snapshot del índice:", err)
+			}
+		}
+	}
+}
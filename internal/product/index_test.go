@@ -0,0 +1,41 @@
+package product
+
+import "testing"
+
+// TestTopKByCategoryOrderingAndExclusion comprueba que TopKByCategory respeta
+// el orden por defecto del índice (Stars desc, ID como desempate), excluye
+// los IDs marcados y corta exactamente en k.
+func TestTopKByCategoryOrderingAndExclusion(t *testing.T) {
+	idx := NewIndex(map[string]Product{
+		"a": {ID: "a", Category: "books", Stars: 4.5},
+		"b": {ID: "b", Category: "books", Stars: 4.8},
+		"c": {ID: "c", Category: "books", Stars: 4.8}, // empata con "b", desempata por ID
+		"d": {ID: "d", Category: "books", Stars: 3.0},
+		"e": {ID: "e", Category: "toys", Stars: 5.0},
+	})
+
+	got := idx.TopKByCategory("books", 2, map[string]bool{"b": true}, nil)
+
+	want := []string{"c", "a"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d productos, se esperaban %d: %v", len(got), len(want), got)
+	}
+	for i, id := range want {
+		if got[i].ID != id {
+			t.Errorf("got[%d].ID = %q, se esperaba %q", i, got[i].ID, id)
+		}
+	}
+}
+
+// TestTopKByCategoryUnknownCategory comprueba que una categoría inexistente
+// devuelve una lista vacía en vez de entrar en pánico.
+func TestTopKByCategoryUnknownCategory(t *testing.T) {
+	idx := NewIndex(map[string]Product{
+		"a": {ID: "a", Category: "books", Stars: 4.5},
+	})
+
+	got := idx.TopKByCategory("unknown", 5, nil, nil)
+	if len(got) != 0 {
+		t.Fatalf("got %v, se esperaba una lista vacía", got)
+	}
+}
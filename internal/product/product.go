@@ -0,0 +1,61 @@
+// Package product contiene el modelo de dominio compartido por el receiver
+// y el recommender: el tipo Product, el loader de CSV y el índice en
+// memoria usado para las recomendaciones.
+package product
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+)
+
+// Product representa un producto del dataset. Price y ReviewCount son
+// opcionales: el CSV histórico sólo trae id, category y stars, así que el
+// loader los deja en su valor cero cuando la fila no incluye esas columnas.
+type Product struct {
+	ID          string  `json:"id"`
+	Category    string  `json:"category"`
+	Stars       float64 `json:"stars"`
+	Price       float64 `json:"price,omitempty"`
+	ReviewCount int     `json:"review_count,omitempty"`
+}
+
+// LoadDataset carga el dataset desde un archivo CSV.
+func LoadDataset(filePath string) (map[string]Product, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1 // tolerar filas con columnas extra (price, review_count)
+	reader.Read()               // Omitir encabezado
+
+	products := make(map[string]Product)
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			break
+		}
+
+		stars, _ := strconv.ParseFloat(record[2], 64)
+		p := Product{
+			ID:       record[0],
+			Category: record[1],
+			Stars:    stars,
+		}
+		if len(record) > 3 {
+			if price, err := strconv.ParseFloat(record[3], 64); err == nil {
+				p.Price = price
+			}
+		}
+		if len(record) > 4 {
+			if reviewCount, err := strconv.Atoi(record[4]); err == nil {
+				p.ReviewCount = reviewCount
+			}
+		}
+		products[record[0]] = p
+	}
+	return products, nil
+}
@@ -0,0 +1,161 @@
+package receiver
+
+import (
+	"embed"
+	"html/template"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/MarcDiazQ/TF_concurrencia/internal/product"
+)
+
+// productsTemplates está embebida en el binario, con un layout base y una
+// plantilla parcial para la fila de producto, pensada para que futuras
+// vistas (por categoría, por vendedor) puedan reusar "row" sin duplicar el
+// <tr>.
+//
+//go:embed templates/*.html
+var templatesFS embed.FS
+
+var productsTemplates = template.Must(template.ParseFS(templatesFS, "templates/*.html"))
+
+// sortableColumns son las columnas por las que htmlHandler acepta ordenar
+// vía ?sort=.
+var sortableColumns = []struct{ key, label string }{
+	{"id", "ID"},
+	{"category", "Categoría"},
+	{"stars", "Estrellas"},
+}
+
+const (
+	defaultLimit = 50
+	maxLimit     = 500
+)
+
+// listingParams son los parámetros de query ya validados para el listado.
+type listingParams struct {
+	sort   string
+	order  string
+	limit  int
+	offset int
+}
+
+// parseListingParams lee ?sort=, ?order=, ?limit= y ?offset=, cayendo a
+// valores por defecto seguros ante entradas ausentes o inválidas.
+func parseListingParams(values url.Values) listingParams {
+	p := listingParams{sort: "id", order: "asc", limit: defaultLimit, offset: 0}
+
+	if s := values.Get("sort"); s == "category" || s == "stars" {
+		p.sort = s
+	}
+	if o := values.Get("order"); o == "desc" {
+		p.order = o
+	}
+	if limit, err := strconv.Atoi(values.Get("limit")); err == nil && limit > 0 {
+		p.limit = limit
+	}
+	if p.limit > maxLimit {
+		p.limit = maxLimit
+	}
+	if offset, err := strconv.Atoi(values.Get("offset")); err == nil && offset >= 0 {
+		p.offset = offset
+	}
+	return p
+}
+
+// sortProducts ordena products in-place según p.sort/p.order, con el ID
+// como desempate estable.
+func sortProducts(products []product.Product, p listingParams) {
+	less := func(i, j int) bool {
+		a, b := products[i], products[j]
+		switch p.sort {
+		case "category":
+			if a.Category != b.Category {
+				return a.Category < b.Category
+			}
+		case "stars":
+			if a.Stars != b.Stars {
+				return a.Stars < b.Stars
+			}
+		default:
+			return a.ID < b.ID
+		}
+		return a.ID < b.ID
+	}
+	sort.SliceStable(products, func(i, j int) bool {
+		if p.order == "desc" {
+			return less(j, i)
+		}
+		return less(i, j)
+	})
+}
+
+// columnHeader es lo que consume templates/products.html para pintar cada
+// cabecera como un toggle de orden.
+type columnHeader struct {
+	Label string
+	URL   string
+	Arrow string
+}
+
+// listingView es el modelo pasado a la plantilla "base".
+type listingView struct {
+	Products []product.Product
+	Total    int
+	Offset   int
+	Columns  []columnHeader
+	HasPrev  bool
+	HasNext  bool
+	PrevURL  string
+	NextURL  string
+}
+
+// buildListingView arma el modelo de vista: cabeceras con su link de
+// ordenación y los links de paginación, a partir de los parámetros ya
+// aplicados a la página actual.
+func buildListingView(page []product.Product, p listingParams, total int) listingView {
+	columns := make([]columnHeader, 0, len(sortableColumns))
+	for _, col := range sortableColumns {
+		nextOrder, arrow := "asc", ""
+		if col.key == p.sort {
+			if p.order == "asc" {
+				nextOrder, arrow = "desc", "▲"
+			} else {
+				nextOrder, arrow = "asc", "▼"
+			}
+		}
+		columns = append(columns, columnHeader{
+			Label: col.label,
+			URL:   pageURL(col.key, nextOrder, p.limit, 0),
+			Arrow: arrow,
+		})
+	}
+
+	return listingView{
+		Products: page,
+		Total:    total,
+		Offset:   p.offset,
+		Columns:  columns,
+		HasPrev:  p.offset > 0,
+		HasNext:  p.offset+len(page) < total,
+		PrevURL:  pageURL(p.sort, p.order, p.limit, maxInt(p.offset-p.limit, 0)),
+		NextURL:  pageURL(p.sort, p.order, p.limit, p.offset+p.limit),
+	}
+}
+
+func pageURL(sortBy, order string, limit, offset int) string {
+	values := url.Values{}
+	values.Set("sort", sortBy)
+	values.Set("order", order)
+	values.Set("limit", strconv.Itoa(limit))
+	values.Set("offset", strconv.Itoa(offset))
+	return "?" + values.Encode()
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
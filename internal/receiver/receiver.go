@@ -0,0 +1,252 @@
+// Package receiver implementa el servicio "receiver": el sumidero TCP que
+// recibe lotes de recomendaciones y el visor HTML que los lista.
+package receiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/MarcDiazQ/TF_concurrencia/internal/config"
+	"github.com/MarcDiazQ/TF_concurrencia/internal/httpmw"
+	"github.com/MarcDiazQ/TF_concurrencia/internal/product"
+	"github.com/MarcDiazQ/TF_concurrencia/internal/transport"
+)
+
+// state agrupa el almacenamiento en memoria de los productos recibidos y su
+// mutex, para no depender de variables globales de paquete.
+type state struct {
+	mu       sync.Mutex
+	products []product.Product
+	ready    atomic.Bool
+}
+
+// Run arranca el receptor TCP y el servidor HTTP de listado, y bloquea
+// hasta que ctx se cancela, momento en el que apaga ambos ordenadamente.
+func Run(ctx context.Context, cfg *config.Config) error {
+	st := &state{}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		st.tcpReceiver(ctx, cfg)
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", st.htmlHandler)
+	mux.HandleFunc("/healthz", healthzHandler)
+	mux.HandleFunc("/readyz", st.readyzHandler)
+
+	httpServer := &http.Server{Addr: ":" + cfg.ReceiverHTTPPort, Handler: httpmw.CORS(cfg.CORSOrigins)(mux)}
+	httpErrs := make(chan error, 1)
+	go func() {
+		fmt.Println("Servidor HTTP del receiver escuchando en el puerto", cfg.ReceiverHTTPPort)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			httpErrs <- err
+			return
+		}
+		httpErrs <- nil
+	}()
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+	case err := <-httpErrs:
+		if err != nil {
+			fmt.Println("Error al iniciar el servidor HTTP del receiver:", err)
+			runErr = err
+			cancel() // el TCP receiver sólo se detiene cuando ctx se cancela
+		}
+	}
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		fmt.Println("Error al apagar el servidor HTTP del receiver:", err)
+		if runErr == nil {
+			runErr = err
+		}
+	}
+
+	wg.Wait()
+	return runErr
+}
+
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func (st *state) readyzHandler(w http.ResponseWriter, r *http.Request) {
+	if !st.ready.Load() {
+		http.Error(w, "tcp receiver not listening yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// tcpReceiver escucha conexiones TCP. Cierra el listener y deja de aceptar
+// conexiones nuevas en cuanto ctx se cancela, y espera a que las conexiones
+// en curso terminen antes de devolver el control.
+func (st *state) tcpReceiver(ctx context.Context, cfg *config.Config) {
+	listener, err := net.Listen("tcp", ":"+cfg.ReceiverTCPPort)
+	if err != nil {
+		fmt.Println("Error al iniciar el servidor TCP:", err)
+		return
+	}
+	defer listener.Close()
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	fmt.Println("Servidor TCP escuchando en el puerto", cfg.ReceiverTCPPort)
+	st.ready.Store(true)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	// sem acota el número de conexiones atendidas simultáneamente.
+	sem := make(chan struct{}, cfg.MaxConns)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return
+			default:
+				fmt.Println("Error al aceptar conexión:", err)
+				continue
+			}
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			st.handleTCPConnection(conn, cfg)
+		}()
+	}
+}
+
+// handleTCPConnection lee un frame de tipo batch de productos y responde
+// con un frame de ack (o de error si la decodificación falla).
+func (st *state) handleTCPConnection(conn net.Conn, cfg *config.Config) {
+	defer conn.Close()
+
+	if err := conn.SetReadDeadline(time.Now().Add(cfg.ReadTimeout)); err != nil {
+		fmt.Println("Error al fijar el read deadline:", err)
+		return
+	}
+
+	msgType, payload, err := transport.ReadFrame(conn)
+	if err != nil {
+		fmt.Println("Error al leer el frame:", err)
+		return
+	}
+	if msgType != transport.MsgTypeProductBatch {
+		fmt.Printf("Tipo de mensaje inesperado: 0x%02x\n", msgType)
+		return
+	}
+
+	var products []product.Product
+	if err := json.Unmarshal(payload, &products); err != nil {
+		fmt.Println("Error al decodificar datos:", err)
+		st.writeAck(conn, cfg, err)
+		return
+	}
+
+	st.mu.Lock()
+	st.products = append(st.products, products...)
+	st.mu.Unlock()
+
+	fmt.Println("Productos recibidos y almacenados.")
+	st.writeAck(conn, cfg, nil)
+}
+
+// writeAck envía un frame MsgTypeAck confirmando el resultado del
+// procesado, incluyendo el mensaje de error si lo hubo.
+func (st *state) writeAck(conn net.Conn, cfg *config.Config, ackErr error) {
+	ack := struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error,omitempty"`
+	}{OK: ackErr == nil}
+	if ackErr != nil {
+		ack.Error = ackErr.Error()
+	}
+
+	payload, err := json.Marshal(ack)
+	if err != nil {
+		fmt.Println("Error al serializar el ack:", err)
+		return
+	}
+
+	if err := conn.SetWriteDeadline(time.Now().Add(cfg.WriteTimeout)); err != nil {
+		fmt.Println("Error al fijar el write deadline:", err)
+		return
+	}
+	if err := transport.WriteFrame(conn, transport.MsgTypeAck, payload); err != nil {
+		fmt.Println("Error al enviar el ack:", err)
+	}
+}
+
+// htmlHandler sirve el listado de productos recibidos: soporta orden,
+// paginación y content negotiation (JSON si el cliente pide
+// "application/json", HTML con la plantilla embebida en caso contrario).
+func (st *state) htmlHandler(w http.ResponseWriter, r *http.Request) {
+	params := parseListingParams(r.URL.Query())
+
+	st.mu.Lock()
+	products := make([]product.Product, len(st.products))
+	copy(products, st.products)
+	st.mu.Unlock()
+
+	sortProducts(products, params)
+
+	total := len(products)
+	offset := params.offset
+	if offset > total {
+		offset = total
+	}
+	end := offset + params.limit
+	if end > total {
+		end = total
+	}
+	page := products[offset:end]
+	params.offset = offset
+
+	if wantsJSON(r) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Products []product.Product `json:"products"`
+			Total    int               `json:"total"`
+			Sort     string            `json:"sort"`
+			Order    string            `json:"order"`
+			Limit    int               `json:"limit"`
+			Offset   int               `json:"offset"`
+		}{Products: page, Total: total, Sort: params.sort, Order: params.order, Limit: params.limit, Offset: offset})
+		return
+	}
+
+	view := buildListingView(page, params, total)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := productsTemplates.ExecuteTemplate(w, "base", view); err != nil {
+		fmt.Println("Error al renderizar la plantilla:", err)
+	}
+}
+
+// wantsJSON decide el content negotiation según la cabecera Accept.
+func wantsJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
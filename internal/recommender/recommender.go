@@ -0,0 +1,202 @@
+// Package recommender implementa el servicio "recommender": la API REST de
+// recomendaciones sobre el dataset de productos.
+package recommender
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/MarcDiazQ/TF_concurrencia/internal/config"
+	"github.com/MarcDiazQ/TF_concurrencia/internal/httpmw"
+	"github.com/MarcDiazQ/TF_concurrencia/internal/product"
+	"github.com/MarcDiazQ/TF_concurrencia/internal/transport"
+)
+
+// service agrupa el estado del recommender: el índice del dataset y su
+// estado de preparación, en vez de variables globales de paquete.
+type service struct {
+	index *product.Index
+	cfg   *config.Config
+	ready atomic.Bool
+}
+
+// Run carga el dataset (desde snapshot o CSV), arranca el servidor HTTP de
+// la API y el volcado periódico del índice, y bloquea hasta que ctx se
+// cancela.
+func Run(ctx context.Context, cfg *config.Config) error {
+	svc := &service{cfg: cfg}
+
+	// Preferir el snapshot en disco para evitar reparsear el CSV en cada
+	// arranque; si no existe o está corrupto, caer al CSV original.
+	products, err := product.LoadSnapshot(cfg.SnapshotPath)
+	if err != nil {
+		fmt.Println("No se pudo cargar el snapshot, cargando desde CSV:", err)
+		products, err = product.LoadDataset(cfg.DatasetPath)
+		if err != nil {
+			return fmt.Errorf("recommender: error al cargar el dataset: %w", err)
+		}
+	}
+	svc.index = product.NewIndex(products)
+	svc.ready.Store(true)
+
+	go svc.index.PersistPeriodically(ctx, cfg.SnapshotPath, cfg.SnapshotInterval)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/recommendations", svc.productRecommendationHandler)
+	mux.HandleFunc("/healthz", healthzHandler)
+	mux.HandleFunc("/readyz", svc.readyzHandler)
+
+	httpServer := &http.Server{Addr: ":" + cfg.RecommenderHTTPPort, Handler: httpmw.CORS(cfg.CORSOrigins)(mux)}
+	httpErrs := make(chan error, 1)
+	go func() {
+		fmt.Println("API REST del recommender corriendo en el puerto", cfg.RecommenderHTTPPort)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			httpErrs <- err
+			return
+		}
+		httpErrs <- nil
+	}()
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+	case err := <-httpErrs:
+		if err != nil {
+			fmt.Println("Error al iniciar el servidor del recommender:", err)
+			runErr = err
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil && runErr == nil {
+		runErr = err
+	}
+	return runErr
+}
+
+func healthzHandler(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func (svc *service) readyzHandler(w http.ResponseWriter, r *http.Request) {
+	if !svc.ready.Load() {
+		http.Error(w, "dataset not loaded yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// findBestRecommendations busca los k mejores productos por categoría
+// (excluyendo los originales), apoyándose en el índice invertido por
+// categoría en vez de recorrer todo el dataset por cada categoría
+// solicitada. cmp es nil para usar el orden pre-calculado del índice
+// (by_stars_then_id); si no, reordena antes de recortar al top-k.
+func findBestRecommendations(idx *product.Index, productIDs []string, k int, cmp product.Comparator) []product.Product {
+	categorySet := make(map[string]bool)
+	originalProducts := make(map[string]bool)
+
+	for _, id := range productIDs {
+		id = strings.TrimSpace(id)
+		p, exists := idx.Lookup(id)
+		if exists {
+			originalProducts[id] = true
+			categorySet[p.Category] = true
+		} else {
+			fmt.Printf("Product ID not found in dataset: %s\n", id)
+		}
+	}
+
+	bestProducts := []product.Product{}
+	for category := range categorySet {
+		bestProducts = append(bestProducts, idx.TopKByCategory(category, k, originalProducts, cmp)...)
+	}
+
+	return bestProducts
+}
+
+// productRecommendationHandler expone /api/recommendations.
+func (svc *service) productRecommendationHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var requestBody struct {
+		ProductIDs string `json:"product_ids"`
+		K          int    `json:"k"`
+		Ranker     string `json:"ranker"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&requestBody); err != nil {
+		http.Error(w, "Invalid JSON format", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+
+	k := requestBody.K
+	if k <= 0 {
+		k = 1
+	}
+
+	// El orden pre-calculado del índice ya es by_stars_desc (con desempate
+	// por ID), así que ese caso usa cmp=nil y se salta el reordenado.
+	var cmp product.Comparator
+	rankerName := requestBody.Ranker
+	if rankerName == "" {
+		rankerName = "by_stars_desc"
+	}
+	if rankerName != "by_stars_desc" {
+		found, ok := product.LookupComparator(rankerName)
+		if !ok {
+			http.Error(w, "Unknown ranker: "+rankerName, http.StatusBadRequest)
+			return
+		}
+		cmp = found
+	}
+
+	productIDs := strings.Split(requestBody.ProductIDs, ",")
+	bestProducts := findBestRecommendations(svc.index, productIDs, k, cmp)
+
+	fmt.Println("Recommended products:", bestProducts)
+
+	response, err := svc.sendToReceiver(r.Context(), bestProducts)
+	if err != nil {
+		switch {
+		case errors.Is(err, transport.ErrConnect):
+			http.Error(w, "Error connecting to server: "+err.Error(), http.StatusBadGateway)
+		case errors.Is(err, transport.ErrTimeout):
+			http.Error(w, "Timeout connecting to server: "+err.Error(), http.StatusGatewayTimeout)
+		case errors.Is(err, transport.ErrProtocol):
+			http.Error(w, "Protocol error talking to server: "+err.Error(), http.StatusBadGateway)
+		default:
+			http.Error(w, "Error connecting to server: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
+
+// sendToReceiver envía los productos recomendados al receiver usando un
+// Sender: escribe un batch de productos y espera un único frame de ack a
+// cambio, con reintentos y backoff si la conexión falla o expira, y
+// cancelable vía ctx si el cliente HTTP se desconecta.
+func (svc *service) sendToReceiver(ctx context.Context, bestProducts []product.Product) ([]byte, error) {
+	data, err := json.Marshal(bestProducts)
+	if err != nil {
+		return nil, err
+	}
+
+	return transport.NewSender(svc.cfg.ReceiverAddress).
+		Body(data).
+		Retry(svc.cfg.SenderRetries, svc.cfg.SenderBackoff).
+		Deadline(svc.cfg.SenderDeadline).
+		Do(ctx)
+}
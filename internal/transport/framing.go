@@ -0,0 +1,73 @@
+// Package transport contiene el protocolo de framing TCP compartido entre
+// el receiver y el recommender, y el Sender que lo usa para hablar con el
+// receiver con reintentos.
+package transport
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Tipos de mensaje del protocolo de framing TCP. Cada mensaje escrito con
+// WriteFrame lleva, tras el length-prefix, un byte que identifica su contenido, de modo que
+// una misma conexión pueda transportar varios mensajes en secuencia en vez
+// de depender de que el emisor cierre la conexión para marcar el final.
+const (
+	MsgTypeProductBatch         byte = 0x01
+	MsgTypeRecommendationResult byte = 0x02
+	MsgTypeAck                  byte = 0x03
+)
+
+// MaxFramePayload limita el tamaño de un frame para que un length-prefix
+// corrupto o malicioso no provoque una asignación de memoria desmedida.
+const MaxFramePayload = 10 << 20 // 10 MiB
+
+var ErrFrameTooLarge = errors.New("framing: el payload excede el tamaño máximo de frame")
+
+// WriteFrame escribe un mensaje como [4 bytes longitud big-endian][1 byte tipo][payload].
+// La longitud cubre el byte de tipo más el payload.
+func WriteFrame(w io.Writer, msgType byte, payload []byte) error {
+	header := make([]byte, 5)
+	binary.BigEndian.PutUint32(header[:4], uint32(len(payload)+1))
+	header[4] = msgType
+
+	if _, err := w.Write(header); err != nil {
+		return fmt.Errorf("framing: error al escribir la cabecera: %w", err)
+	}
+	if len(payload) == 0 {
+		return nil
+	}
+	if _, err := w.Write(payload); err != nil {
+		return fmt.Errorf("framing: error al escribir el payload: %w", err)
+	}
+	return nil
+}
+
+// ReadFrame lee un único mensaje escrito por WriteFrame. Usa io.ReadFull
+// para que un read parcial (habitual en TCP) no se confunda con un frame
+// corto.
+func ReadFrame(r io.Reader) (msgType byte, payload []byte, err error) {
+	header := make([]byte, 5)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, nil, err
+	}
+
+	length := binary.BigEndian.Uint32(header[:4])
+	if length == 0 {
+		return 0, nil, fmt.Errorf("framing: frame vacío")
+	}
+	if length > MaxFramePayload {
+		return 0, nil, ErrFrameTooLarge
+	}
+
+	msgType = header[4]
+	payload = make([]byte, length-1)
+	if len(payload) > 0 {
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return 0, nil, fmt.Errorf("framing: error al leer el payload: %w", err)
+		}
+	}
+	return msgType, payload, nil
+}
@@ -0,0 +1,79 @@
+package transport
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+// TestWriteReadFrameRoundTrip comprueba que ReadFrame reconstruye exactamente
+// lo que escribió WriteFrame, incluyendo el caso de payload vacío.
+func TestWriteReadFrameRoundTrip(t *testing.T) {
+	cases := []struct {
+		name    string
+		msgType byte
+		payload []byte
+	}{
+		{"payload normal", MsgTypeProductBatch, []byte(`[{"id":"1"}]`)},
+		{"payload vacío", MsgTypeAck, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WriteFrame(&buf, tc.msgType, tc.payload); err != nil {
+				t.Fatalf("WriteFrame: %v", err)
+			}
+
+			msgType, payload, err := ReadFrame(&buf)
+			if err != nil {
+				t.Fatalf("ReadFrame: %v", err)
+			}
+			if msgType != tc.msgType {
+				t.Errorf("msgType = 0x%02x, se esperaba 0x%02x", msgType, tc.msgType)
+			}
+			if !bytes.Equal(payload, tc.payload) {
+				t.Errorf("payload = %q, se esperaba %q", payload, tc.payload)
+			}
+		})
+	}
+}
+
+// TestReadFrameTooLarge comprueba que un length-prefix que supera
+// MaxFramePayload se rechaza sin intentar reservar memoria para el payload.
+func TestReadFrameTooLarge(t *testing.T) {
+	var buf bytes.Buffer
+	header := make([]byte, 5)
+	header[0] = 0xFF // longitud absurdamente grande en el byte más significativo
+	buf.Write(header)
+
+	_, _, err := ReadFrame(&buf)
+	if !errors.Is(err, ErrFrameTooLarge) {
+		t.Fatalf("err = %v, se esperaba ErrFrameTooLarge", err)
+	}
+}
+
+// TestReadFramePartialHeader comprueba que un read parcial de la cabecera
+// (la conexión se corta a medias) se reporta como error en vez de devolver
+// un frame corrupto.
+func TestReadFramePartialHeader(t *testing.T) {
+	buf := bytes.NewReader([]byte{0x00, 0x00, 0x00})
+
+	_, _, err := ReadFrame(buf)
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("err = %v, se esperaba io.ErrUnexpectedEOF", err)
+	}
+}
+
+// TestReadFrameEmptyLength comprueba que un length-prefix de 0 (que no deja
+// ni siquiera sitio para el byte de tipo) se rechaza explícitamente.
+func TestReadFrameEmptyLength(t *testing.T) {
+	var buf bytes.Buffer
+	buf.Write([]byte{0x00, 0x00, 0x00, 0x00, MsgTypeAck})
+
+	_, _, err := ReadFrame(&buf)
+	if err == nil {
+		t.Fatal("se esperaba un error con longitud 0")
+	}
+}
@@ -0,0 +1,171 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"time"
+)
+
+// Errores tipados que un handler HTTP puede distinguir para devolver el
+// código de estado adecuado en vez de StatusInternalServerError siempre.
+var (
+	ErrConnect  = errors.New("sender: no se pudo conectar con el servidor de procesado")
+	ErrTimeout  = errors.New("sender: se superó el deadline de la operación")
+	ErrProtocol = errors.New("sender: respuesta con formato de protocolo inválido")
+)
+
+// Sender construye una petición TCP framed con un estilo de builder fluido
+// (URL/Header/Body/Retry/Deadline encadenados, Do(ctx) ejecuta la
+// petición).
+type Sender struct {
+	address string
+	headers map[string]string
+	body    []byte
+	retries int
+	backoff time.Duration
+	timeout time.Duration
+}
+
+// NewSender crea un Sender apuntando a address ("host:puerto"), con un único
+// intento y un deadline de 10s por defecto.
+func NewSender(address string) *Sender {
+	return &Sender{
+		address: address,
+		headers: make(map[string]string),
+		retries: 1,
+		backoff: 100 * time.Millisecond,
+		timeout: 10 * time.Second,
+	}
+}
+
+// URL cambia la dirección de destino.
+func (s *Sender) URL(address string) *Sender {
+	s.address = address
+	return s
+}
+
+// Header añade metadatos asociados a la petición. El protocolo framed actual
+// sólo transporta tipo+payload, así que de momento quedan reservados para
+// cuando se añada un transporte que sepa llevarlos (p. ej. HTTP).
+func (s *Sender) Header(key, value string) *Sender {
+	s.headers[key] = value
+	return s
+}
+
+// Body fija el payload (ya serializado) que se enviará como MsgTypeProductBatch.
+func (s *Sender) Body(body []byte) *Sender {
+	s.body = body
+	return s
+}
+
+// Retry fija el número de intentos y el backoff base entre ellos. n < 1 se
+// trata como 1 intento: Do siempre debe intentar al menos una vez.
+func (s *Sender) Retry(n int, backoff time.Duration) *Sender {
+	if n < 1 {
+		n = 1
+	}
+	s.retries = n
+	s.backoff = backoff
+	return s
+}
+
+// Deadline fija el deadline de conexión/lectura/escritura de cada intento.
+func (s *Sender) Deadline(d time.Duration) *Sender {
+	s.timeout = d
+	return s
+}
+
+// Do ejecuta la petición, reintentando con backoff exponencial y jitter
+// mientras el error sea de conexión o de timeout. ctx permite que el
+// llamador (p. ej. el handler HTTP) cancele el intento en curso si el
+// cliente se desconecta.
+func (s *Sender) Do(ctx context.Context) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt < s.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoffWithJitter(s.backoff, attempt)):
+			case <-ctx.Done():
+				return nil, fmt.Errorf("%w: %v", ErrTimeout, ctx.Err())
+			}
+		}
+
+		payload, err := s.attempt(ctx)
+		if err == nil {
+			return payload, nil
+		}
+		lastErr = err
+		if !errors.Is(err, ErrConnect) && !errors.Is(err, ErrTimeout) {
+			return nil, err
+		}
+	}
+	return nil, lastErr
+}
+
+func (s *Sender) attempt(ctx context.Context) ([]byte, error) {
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		if isTimeoutErr(err) {
+			return nil, fmt.Errorf("%w: %v", ErrTimeout, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrConnect, err)
+	}
+	defer conn.Close()
+
+	// Cierra la conexión si el contexto se cancela mientras el intento está
+	// en curso, para que un cliente HTTP desconectado no deje el TCP colgado.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	if s.timeout > 0 {
+		if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrConnect, err)
+		}
+	}
+
+	if err := WriteFrame(conn, MsgTypeProductBatch, s.body); err != nil {
+		if isTimeoutErr(err) {
+			return nil, fmt.Errorf("%w: %v", ErrTimeout, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrProtocol, err)
+	}
+
+	msgType, payload, err := ReadFrame(conn)
+	if err != nil {
+		if isTimeoutErr(err) {
+			return nil, fmt.Errorf("%w: %v", ErrTimeout, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrProtocol, err)
+	}
+	if msgType != MsgTypeAck {
+		return nil, fmt.Errorf("%w: tipo de mensaje inesperado 0x%02x", ErrProtocol, msgType)
+	}
+
+	return payload, nil
+}
+
+func isTimeoutErr(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// backoffWithJitter aplica "full jitter": un retraso aleatorio entre 0 y
+// base*2^attempt, para evitar que reintentos simultáneos se sincronicen.
+func backoffWithJitter(base time.Duration, attempt int) time.Duration {
+	max := base << uint(attempt)
+	if max <= 0 {
+		return base
+	}
+	return time.Duration(rand.Int63n(int64(max)))
+}
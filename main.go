@@ -0,0 +1,97 @@
+// Command tfconcurrencia agrupa los dos servicios del TF en un único
+// binario con subcomandos: "receiver" (el sumidero TCP + visor HTML),
+// "recommender" (la API REST de recomendaciones) y "all" (ambos en el mismo
+// proceso).
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/MarcDiazQ/TF_concurrencia/internal/config"
+	"github.com/MarcDiazQ/TF_concurrencia/internal/receiver"
+	"github.com/MarcDiazQ/TF_concurrencia/internal/recommender"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	cmd, args := os.Args[1], os.Args[2:]
+
+	var err error
+	switch cmd {
+	case "receiver":
+		err = runReceiver(args)
+	case "recommender":
+		err = runRecommender(args)
+	case "all":
+		err = runAll(args)
+	case "-h", "--help", "help":
+		printUsage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "subcomando desconocido: %s\n", cmd)
+		printUsage()
+		os.Exit(1)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "uso: tfconcurrencia <receiver|recommender|all> [flags]")
+}
+
+func rootContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
+}
+
+func runReceiver(args []string) error {
+	cfg, err := config.Load("receiver", args)
+	if err != nil {
+		return err
+	}
+	ctx, stop := rootContext()
+	defer stop()
+	return receiver.Run(ctx, cfg)
+}
+
+func runRecommender(args []string) error {
+	cfg, err := config.Load("recommender", args)
+	if err != nil {
+		return err
+	}
+	ctx, stop := rootContext()
+	defer stop()
+	return recommender.Run(ctx, cfg)
+}
+
+// runAll arranca ambos servicios en el mismo proceso con la misma
+// configuración, usando un errgroup.Group para que la cancelación del
+// ctx derivado se propague al otro servicio en cuanto cualquiera de los
+// dos falla o llega la señal de apagado.
+func runAll(args []string) error {
+	cfg, err := config.Load("all", args)
+	if err != nil {
+		return err
+	}
+	ctx, stop := rootContext()
+	defer stop()
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() error { return receiver.Run(ctx, cfg) })
+	g.Go(func() error { return recommender.Run(ctx, cfg) })
+
+	return g.Wait()
+}